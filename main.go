@@ -3,22 +3,35 @@ package main
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
 	"log"
+	"net/http"
 	"os"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/fullstorydev/grpcurl"
 	"github.com/golang/protobuf/jsonpb"
 	"github.com/golang/protobuf/proto" //lint:ignore SA1019 same as above
 	"github.com/jhump/protoreflect/desc"
+	"github.com/jhump/protoreflect/desc/protoparse"
+	"github.com/jhump/protoreflect/dynamic"
 	"github.com/jhump/protoreflect/grpcreflect"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+	"google.golang.org/genproto/googleapis/api/annotations"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
 	"google.golang.org/grpc/status"
@@ -30,6 +43,7 @@ type customEventHandler struct {
 	*grpcurl.DefaultEventHandler
 	headers  metadata.MD
 	trailers metadata.MD
+	messages []streamedMessage
 }
 
 // OnReceiveTrailers captures the incoming trailer metadata
@@ -44,6 +58,40 @@ func (h *customEventHandler) OnReceiveTrailers(status *status.Status, md metadat
 	h.DefaultEventHandler.OnReceiveTrailers(status, md)
 }
 
+// OnReceiveResponse accumulates every message the server sends, tagged with
+// the time it arrived, instead of relying on the DefaultEventHandler's single
+// formatted buffer. This lets server-streaming and bidi-streaming responses
+// be returned as an ordered list rather than a concatenated blob of text.
+func (h *customEventHandler) OnReceiveResponse(msg proto.Message) {
+	h.DefaultEventHandler.NumResponses++
+	str, err := h.DefaultEventHandler.Formatter(msg)
+	if err != nil {
+		str = fmt.Sprintf("<failed to format response: %v>", err)
+	}
+	h.messages = append(h.messages, streamedMessage{
+		Timestamp: time.Now(),
+		Body:      json.RawMessage(str),
+	})
+}
+
+// streamedMessage is a single response message received from the server,
+// paired with the time it was received so callers can reason about timing
+// for server-streaming and bidi-streaming RPCs.
+type streamedMessage struct {
+	Timestamp time.Time       `json:"timestamp"`
+	Body      json.RawMessage `json:"body"`
+}
+
+// describeResult is a single entry in the describe tool's JSON response.
+// Template is populated for message types, and for method types (from their
+// input type), so downstream tooling can consume it without scraping text.
+type describeResult struct {
+	Entity      string `json:"entity"`
+	Type        string `json:"type"`
+	Description string `json:"description"`
+	Template    string `json:"template,omitempty"`
+}
+
 // NewGrpcReflectionServer creates a new GrpcReflectionServer for the given target address.
 func NewGrpcReflectionServer(host string) *GrpcReflectionServer {
 	srv := server.NewMCPServer(
@@ -53,9 +101,12 @@ func NewGrpcReflectionServer(host string) *GrpcReflectionServer {
 	)
 
 	grs := &GrpcReflectionServer{
-		srv:     srv,
-		host:    host,
-		headers: make(map[string]string),
+		srv:       srv,
+		host:      host,
+		headers:   make(map[string]string),
+		connCfg:   connectionConfig{Plaintext: true},
+		schemaTTL: 30 * time.Second,
+		connCache: make(map[string]*cachedConn),
 	}
 
 	grs.registerTools()
@@ -114,23 +165,167 @@ Parameters:
 		return toolSuccess(fmt.Sprintf("Headers updated successfully:\n%s", string(jsonResponse))), nil
 	})
 
+	// Tool: configure-connection
+	configureConnectionTool := mcp.NewTool(
+		"configure-connection",
+		mcp.WithDescription(`Configures how future gRPC connections (invoke, list, describe) are dialed.
+Parameters:
+ - "mode": "plaintext" or "tls" (default "plaintext").
+ - "insecure_skip_verify": (Optional) Boolean to skip server certificate verification when using TLS.
+ - "ca_cert": (Optional) PEM-encoded CA certificate used to verify the server.
+ - "client_cert"/"client_key": (Optional) PEM-encoded client certificate and key, for mutual TLS.
+ - "server_name": (Optional) Overrides the server name used for SNI and certificate verification (authority override).
+ - "unix": (Optional) Boolean to dial the target as a Unix domain socket instead of TCP.
+ - "schema_ttl_seconds": (Optional) How long a cached connection's descriptor schema is trusted before being re-queried. Defaults to 30.
+Changing these settings takes effect on each target's next invoke/list/describe call, which redials under the new settings and closes the connection it replaces.`),
+		mcp.WithString("mode", mcp.Description(`"plaintext" or "tls"`)),
+		mcp.WithBoolean("insecure_skip_verify", mcp.Description("Skip server certificate verification")),
+		mcp.WithString("ca_cert", mcp.Description("PEM-encoded CA certificate")),
+		mcp.WithString("client_cert", mcp.Description("PEM-encoded client certificate for mTLS")),
+		mcp.WithString("client_key", mcp.Description("PEM-encoded client key for mTLS")),
+		mcp.WithString("server_name", mcp.Description("Override the server name for SNI/authority")),
+		mcp.WithBoolean("unix", mcp.Description("Dial the target as a Unix domain socket")),
+		mcp.WithNumber("schema_ttl_seconds", mcp.Description("How long a cached schema is trusted before re-querying (default 30)")),
+	)
+	g.srv.AddTool(configureConnectionTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := request.Params.Arguments
+		mode, _ := args["mode"].(string)
+		insecureSkipVerify, _ := args["insecure_skip_verify"].(bool)
+		caCert, _ := args["ca_cert"].(string)
+		clientCert, _ := args["client_cert"].(string)
+		clientKey, _ := args["client_key"].(string)
+		serverName, _ := args["server_name"].(string)
+		unix, _ := args["unix"].(bool)
+		schemaTTLSeconds, hasSchemaTTL := args["schema_ttl_seconds"].(float64)
+
+		if mode != "" && mode != "plaintext" && mode != "tls" {
+			return toolError(fmt.Sprintf("invalid mode %q: must be \"plaintext\" or \"tls\"", mode)), nil
+		}
+
+		cfg := connectionConfig{
+			Plaintext:          mode != "tls",
+			InsecureSkipVerify: insecureSkipVerify,
+			CACert:             caCert,
+			ClientCert:         clientCert,
+			ClientKey:          clientKey,
+			ServerName:         serverName,
+			Unix:               unix,
+		}
+		if _, err := cfg.transportCredentials(); err != nil {
+			return toolError("Invalid TLS configuration: " + err.Error()), nil
+		}
+
+		g.connCfg = cfg
+		if hasSchemaTTL {
+			g.schemaTTL = time.Duration(schemaTTLSeconds * float64(time.Second))
+		}
+		return toolSuccess("Connection settings updated successfully."), nil
+	})
+
+	// Tool: load-protos
+	loadProtosTool := mcp.NewTool(
+		"load-protos",
+		mcp.WithDescription(`Loads local .proto files or a compiled protoset as a descriptor source, for servers that don't support reflection.
+Parameters:
+ - "proto_files": (Optional) Array of .proto file paths to parse.
+ - "import_paths": (Optional) Array of import root directories used to resolve "proto_files" and their imports.
+ - "protoset_path": (Optional) Path to a compiled FileDescriptorSet (protoset) file.
+ - "protoset_base64": (Optional) Base64-encoded compiled FileDescriptorSet, for inline use.
+ - "mode": "replace" (default) to use only the loaded descriptors, or "compose" to merge them with server reflection so locally-known extensions can supplement a partially-reflective server.
+ - "clear": (Optional) Boolean to discard any previously loaded descriptors and go back to reflection alone.
+One of "proto_files", "protoset_path", or "protoset_base64" is required unless "clear" is set. If more than one is given,
+only the first of them in that order is used.`),
+		WithStringArray("proto_files", mcp.Description("Paths to .proto files to parse")),
+		WithStringArray("import_paths", mcp.Description("Import root directories for proto_files")),
+		mcp.WithString("protoset_path", mcp.Description("Path to a compiled FileDescriptorSet file")),
+		mcp.WithString("protoset_base64", mcp.Description("Base64-encoded compiled FileDescriptorSet")),
+		mcp.WithString("mode", mcp.Description(`"replace" (default) or "compose"`)),
+		mcp.WithBoolean("clear", mcp.Description("Discard loaded descriptors and revert to reflection alone")),
+	)
+	g.srv.AddTool(loadProtosTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := request.Params.Arguments
+
+		if clear, _ := args["clear"].(bool); clear {
+			g.protoSource = nil
+			g.composeProtos = false
+			g.bumpProtoGeneration()
+			return toolSuccess("Cleared loaded proto descriptors; reflection will be used."), nil
+		}
+
+		mode, _ := args["mode"].(string)
+		if mode != "" && mode != "replace" && mode != "compose" {
+			return toolError(fmt.Sprintf("invalid mode %q: must be \"replace\" or \"compose\"", mode)), nil
+		}
+
+		protoFiles := stringArrayArg(args, "proto_files")
+		importPaths := stringArrayArg(args, "import_paths")
+		protosetPath, _ := args["protoset_path"].(string)
+		protosetBase64, _ := args["protoset_base64"].(string)
+
+		var (
+			descSource grpcurl.DescriptorSource
+			err        error
+		)
+		switch {
+		case len(protoFiles) > 0:
+			parser := protoparse.Parser{ImportPaths: importPaths}
+			fds, perr := parser.ParseFiles(protoFiles...)
+			if perr != nil {
+				return toolError("Failed to parse proto files: " + perr.Error()), nil
+			}
+			descSource, err = grpcurl.DescriptorSourceFromFileDescriptors(fds...)
+		case protosetPath != "":
+			data, rerr := os.ReadFile(protosetPath)
+			if rerr != nil {
+				return toolError("Failed to read protoset file: " + rerr.Error()), nil
+			}
+			descSource, err = descriptorSourceFromProtosetBytes(data)
+		case protosetBase64 != "":
+			data, derr := base64.StdEncoding.DecodeString(protosetBase64)
+			if derr != nil {
+				return toolError("Failed to decode protoset_base64: " + derr.Error()), nil
+			}
+			descSource, err = descriptorSourceFromProtosetBytes(data)
+		default:
+			return toolError("One of proto_files, protoset_path, or protoset_base64 is required"), nil
+		}
+		if err != nil {
+			return toolError("Failed to build descriptor source: " + err.Error()), nil
+		}
+
+		g.protoSource = descSource
+		g.composeProtos = mode == "compose"
+		g.bumpProtoGeneration()
+
+		return toolSuccess("Loaded local proto descriptors successfully."), nil
+	})
+
 	// Tool 1: invoke
 	invokeTool := mcp.NewTool(
 		"invoke",
 		mcp.WithDescription(`Invokes a gRPC method using reflection.
 Parameters:
  - "method": Fully-qualified method name (e.g., package.Service/Method).
- - "request": JSON payload for the request.
- - "headers": (Optional) JSON object for custom gRPC headers that will be merged with global headers.`),
+ - "request": JSON payload for the request. For a unary or server-streaming
+   method, supply a single JSON object. For a client-streaming or
+   bidirectional-streaming method, supply a JSON array of objects (or a
+   newline-delimited JSON string, one message per line, as accepted by
+   grpcurl's stdin mode); each message is sent in order before the client
+   half-closes the stream.
+ - "headers": (Optional) JSON object for custom gRPC headers that will be merged with global headers.
+ - "host": (Optional) Target address (host:port). Defaults to the ADDRESS environment variable. Connections
+   are cached per host, so repeated calls against the same target reuse the same connection and schema.`),
 		mcp.WithString("method", mcp.Description("Fully-qualified method name (e.g., package.Service/Method)"), mcp.Required()),
 		mcp.WithString("request", mcp.Description("JSON request payload"), mcp.Required()),
 		mcp.WithString("headers", mcp.Description("Optional JSON object for request-specific headers")),
+		mcp.WithString("host", mcp.Description("Target address; defaults to the ADDRESS environment variable")),
 	)
 	g.srv.AddTool(invokeTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		args := request.Params.Arguments
 		method, _ := args["method"].(string)
 		reqPayload, _ := args["request"].(string)
 		requestHeadersJSON, _ := args["headers"].(string)
+		host, _ := args["host"].(string)
 
 		// Parse request-specific headers if provided and merge with global headers
 		headers := []string{}
@@ -151,33 +346,32 @@ Parameters:
 			}
 		}
 
-		// Create a gRPC client connection.
-		network := "tcp"
-		target := g.host
-		dialTime := 10 * time.Second
-
-		dialOptions := []grpc.DialOption{
-			grpc.WithBlock(),
-			grpc.WithTimeout(dialTime),
-			grpc.WithInsecure(), // adjust based on security requirements.
-		}
-
-		cc, err := grpcurl.BlockingDial(ctx, network, target, nil, dialOptions...)
+		// Reuse (or establish) a long-lived connection and descriptor source for this target.
+		conn, err := g.connFor(ctx, host)
 		if err != nil {
 			return toolError("Failed to create gRPC connection: " + err.Error()), nil
 		}
-		defer cc.Close()
+		cc, descSource := conn.cc, conn.descSource
 
-		// Create a reflection client and descriptor source.
-		refClient := grpcreflect.NewClient(ctx, grpc_reflection_v1alpha.NewServerReflectionClient(cc))
-		defer refClient.Reset()
-		descSource := grpcurl.DescriptorSourceFromServer(ctx, refClient)
+		// Resolve the method descriptor so we know whether it is a streaming
+		// RPC and how many request messages it expects.
+		methodDesc, err := findMethodDescriptor(descSource, method)
+		if err != nil {
+			return toolError(fmt.Sprintf("Failed to resolve method %q: %v", method, err)), nil
+		}
 
-		// Create an in-memory buffer to capture output.
-		var outputBuffer bytes.Buffer
+		requestMessages, err := parseRequestMessages(reqPayload)
+		if err != nil {
+			return toolError("Failed to parse request payload: " + err.Error()), nil
+		}
+		if !methodDesc.IsClientStreaming() && len(requestMessages) > 1 {
+			return toolError(fmt.Sprintf("method %s is not client-streaming but %d request messages were supplied", method, len(requestMessages))), nil
+		}
 
-		// Create a formatter (we don't need the parser in the new API).
-		_, formatter, err := grpcurl.RequestParserAndFormatter(grpcurl.FormatJSON, descSource, &outputBuffer, grpcurl.FormatOptions{})
+		// Create a formatter (we don't need the parser in the new API). DefaultEventHandler
+		// only ever writes to Out when VerbosityLevel is above its zero value, which we never
+		// set, so no writer is needed here.
+		_, formatter, err := grpcurl.RequestParserAndFormatter(grpcurl.FormatJSON, descSource, nil, grpcurl.FormatOptions{})
 		if err != nil {
 			return toolError("Failed to create formatter: " + err.Error()), nil
 		}
@@ -185,17 +379,16 @@ Parameters:
 		// Create a custom event handler with header capture capability
 		handler := &customEventHandler{
 			DefaultEventHandler: &grpcurl.DefaultEventHandler{
-				Out:            &outputBuffer,
-				Formatter:      formatter,
-				VerbosityLevel: 0,
-				NumResponses:   0,
-				Status:         nil,
+				Formatter: formatter,
 			},
 		}
 
-		// Create a request supplier that supplies a single JSON message.
-		reqSupplier := &singleMessageSupplier{
-			data: []byte(reqPayload),
+		// Create a request supplier that walks the (possibly multi-message)
+		// request payload, returning io.EOF only after the last message has
+		// been supplied. This drives unary, client-streaming, server-streaming
+		// and bidi-streaming RPCs alike.
+		reqSupplier := &messageSupplier{
+			messages: requestMessages,
 		}
 
 		// Invoke the gRPC method using the new API signature.
@@ -215,7 +408,7 @@ Parameters:
 
 		// Create a structured response with headers and trailers
 		response := map[string]interface{}{
-			"body":     outputBuffer.String(),
+			"messages": handler.messages,
 			"headers":  headersMap,
 			"trailers": trailersMap,
 			"metadata": map[string]interface{}{
@@ -233,35 +426,182 @@ Parameters:
 		return toolSuccess(string(jsonResponse)), nil
 	})
 
+	// Tool: invoke-http
+	invokeHTTPTool := mcp.NewTool(
+		"invoke-http",
+		mcp.WithDescription(`Invokes a gRPC method via its google.api.http (grpc-gateway) annotation, grpc-gateway-style.
+Parameters:
+ - "method": Fully-qualified method name (e.g., package.Service/Method). The method must carry a google.api.http option.
+ - "params": (Optional) JSON object of path-variable and query-parameter values, keyed by request field path
+   (dot-separated for nested fields, matching the names inside the method's URL template).
+ - "body": (Optional) JSON payload routed into the request according to the annotation's "body" selector
+   ("*" for the whole request, a field name for a sub-field, or omitted/empty when the rule has no body).
+ - "headers": (Optional) JSON object for custom gRPC headers that will be merged with global headers.
+ - "host": (Optional) Target address (host:port). Defaults to the ADDRESS environment variable. Connections
+   are cached per host, so repeated calls against the same target reuse the same connection and schema.
+Returns the gRPC response alongside an "http_status" field mapped from the gRPC status code (OK->200, NotFound->404, etc).`),
+		mcp.WithString("method", mcp.Description("Fully-qualified method name (e.g., package.Service/Method)"), mcp.Required()),
+		mcp.WithString("params", mcp.Description("JSON object of path/query parameter values")),
+		mcp.WithString("body", mcp.Description("JSON payload for the HTTP request body")),
+		mcp.WithString("headers", mcp.Description("Optional JSON object for request-specific headers")),
+		mcp.WithString("host", mcp.Description("Target address; defaults to the ADDRESS environment variable")),
+	)
+	g.srv.AddTool(invokeHTTPTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := request.Params.Arguments
+		method, _ := args["method"].(string)
+		paramsJSON, _ := args["params"].(string)
+		bodyPayload, _ := args["body"].(string)
+		requestHeadersJSON, _ := args["headers"].(string)
+		host, _ := args["host"].(string)
+
+		// Parse request-specific headers if provided and merge with global headers
+		headers := []string{}
+		for k, v := range g.headers {
+			headers = append(headers, fmt.Sprintf("%s: %s", k, v))
+		}
+		if requestHeadersJSON != "" {
+			requestHeaders := make(map[string]string)
+			if err := json.Unmarshal([]byte(requestHeadersJSON), &requestHeaders); err != nil {
+				return toolError("Failed to parse headers JSON: " + err.Error()), nil
+			}
+			for k, v := range requestHeaders {
+				headers = append(headers, fmt.Sprintf("%s: %s", k, v))
+			}
+		}
+
+		params := make(map[string]interface{})
+		if paramsJSON != "" {
+			if err := json.Unmarshal([]byte(paramsJSON), &params); err != nil {
+				return toolError("Failed to parse params JSON: " + err.Error()), nil
+			}
+		}
+
+		// Reuse (or establish) a long-lived connection and descriptor source for this target.
+		conn, err := g.connFor(ctx, host)
+		if err != nil {
+			return toolError("Failed to create gRPC connection: " + err.Error()), nil
+		}
+		cc, descSource := conn.cc, conn.descSource
+
+		methodDesc, err := findMethodDescriptor(descSource, method)
+		if err != nil {
+			return toolError(fmt.Sprintf("Failed to resolve method %q: %v", method, err)), nil
+		}
+
+		httpMethod, pathTemplate, bodySelector, err := httpRuleForMethod(methodDesc)
+		if err != nil {
+			return toolError(err.Error()), nil
+		}
+
+		reqMsg := dynamic.NewMessage(methodDesc.GetInputType())
+		consumed := make(map[string]bool)
+
+		// Route the JSON body into the whole request or a sub-field, per the body selector.
+		if bodySelector != "" && bodyPayload != "" {
+			if bodySelector == "*" {
+				if err := jsonpb.Unmarshal(strings.NewReader(bodyPayload), reqMsg); err != nil {
+					return toolError("Failed to parse body JSON: " + err.Error()), nil
+				}
+			} else {
+				fd := reqMsg.GetMessageDescriptor().FindFieldByName(bodySelector)
+				if fd == nil {
+					return toolError(fmt.Sprintf("body selector %q does not name a field on %s", bodySelector, methodDesc.GetInputType().GetFullyQualifiedName())), nil
+				}
+				subMsg := dynamic.NewMessage(fd.GetMessageType())
+				if err := jsonpb.Unmarshal(strings.NewReader(bodyPayload), subMsg); err != nil {
+					return toolError("Failed to parse body JSON: " + err.Error()), nil
+				}
+				if err := reqMsg.TrySetField(fd, subMsg); err != nil {
+					return toolError(fmt.Sprintf("failed to set body field %q: %v", bodySelector, err)), nil
+				}
+				consumed[bodySelector] = true
+			}
+		}
+
+		// Bind the path variables named in the URL template into request fields,
+		// after the body so that path variables always win over a body that
+		// happens to also mention the same field name, per grpc-gateway
+		// semantics. setFieldByPath descends into the submessage the body step
+		// above may have already populated (rather than replacing it), so only
+		// the bound leaf field changes.
+		for _, varName := range parseHTTPPathVars(pathTemplate) {
+			value, ok := params[varName]
+			if !ok {
+				return toolError(fmt.Sprintf("missing value for path variable %q required by %s", varName, pathTemplate)), nil
+			}
+			if err := setFieldByPath(reqMsg, varName, value); err != nil {
+				return toolError(fmt.Sprintf("failed to bind path variable %q: %v", varName, err)), nil
+			}
+			consumed[varName] = true
+		}
+
+		// Merge any remaining params into leaf fields as query parameters.
+		for name, value := range params {
+			if consumed[name] {
+				continue
+			}
+			if err := setFieldByPath(reqMsg, name, value); err != nil {
+				return toolError(fmt.Sprintf("failed to bind query parameter %q: %v", name, err)), nil
+			}
+		}
+
+		_, formatter, err := grpcurl.RequestParserAndFormatter(grpcurl.FormatJSON, descSource, nil, grpcurl.FormatOptions{})
+		if err != nil {
+			return toolError("Failed to create formatter: " + err.Error()), nil
+		}
+		handler := &customEventHandler{
+			DefaultEventHandler: &grpcurl.DefaultEventHandler{
+				Formatter: formatter,
+			},
+		}
+
+		supplier := &dynamicMessageSupplier{msg: reqMsg}
+		if err := grpcurl.InvokeRPC(ctx, descSource, cc, method, headers, handler, supplier.Supply); err != nil {
+			return toolError("Failed to invoke RPC: " + err.Error()), nil
+		}
+
+		response := map[string]interface{}{
+			"messages": handler.messages,
+			"headers":  metadataToMap(handler.headers),
+			"trailers": metadataToMap(handler.trailers),
+			"metadata": map[string]interface{}{
+				"http_method": httpMethod,
+				"path":        pathTemplate,
+				"status_code": handler.Status.Code().String(),
+				"http_status": httpStatusForCode(handler.Status.Code()),
+			},
+		}
+		if handler.Status != nil && handler.Status.Err() != nil {
+			response["error"] = handler.Status.Err().Error()
+		}
+
+		jsonResponse, err := json.MarshalIndent(response, "", "  ")
+		if err != nil {
+			return toolError("Failed to marshal response: " + err.Error()), nil
+		}
+
+		return toolSuccess(string(jsonResponse)), nil
+	})
+
 	// Tool 2: list
 	listTool := mcp.NewTool(
 		"list",
-		mcp.WithDescription("Lists all available gRPC services on the target server using reflection."),
+		mcp.WithDescription(`Lists all available gRPC services on the target server using reflection, or using the descriptors loaded via load-protos when set.
+Parameters:
+ - "host": (Optional) Target address (host:port). Defaults to the ADDRESS environment variable.`),
+		mcp.WithString("host", mcp.Description("Target address; defaults to the ADDRESS environment variable")),
 	)
 	g.srv.AddTool(listTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		// Create a gRPC client connection
-		network := "tcp"
-		target := g.host
-		dialTime := 10 * time.Second
-
-		dialOptions := []grpc.DialOption{
-			grpc.WithBlock(),
-			grpc.WithTimeout(dialTime),
-			grpc.WithInsecure(),
-		}
+		host, _ := request.Params.Arguments["host"].(string)
 
-		cc, err := grpcurl.BlockingDial(ctx, network, target, nil, dialOptions...)
+		// Reuse (or establish) a long-lived connection and descriptor source for this target.
+		conn, err := g.connFor(ctx, host)
 		if err != nil {
 			return toolError("Failed to create gRPC connection: " + err.Error()), nil
 		}
-		defer cc.Close()
-
-		// Create a reflection client
-		refClient := grpcreflect.NewClient(ctx, grpc_reflection_v1alpha.NewServerReflectionClient(cc))
-		defer refClient.Reset()
 
 		// List all services
-		services, err := refClient.ListServices()
+		services, err := conn.descSource.ListServices()
 		if err != nil {
 			return toolError("Failed to list services: " + err.Error()), nil
 		}
@@ -287,33 +627,24 @@ Examples:
  - "mypackage.MyService" to describe the service.
  - "mypackage.MyMessage.MyRpc" to describe a specific RPC method.
  - "mypackage.MyMessage" to describe a message type.
-Note: Slash notation (e.g., "mypackage.MyService/MyMethod") is used for invoking RPCs, not for describing symbols.`),
+Note: Slash notation (e.g., "mypackage.MyService/MyMethod") is used for invoking RPCs, not for describing symbols.
+Returns a JSON array with one entry per entity, each holding its description text and, for message and method
+types, a "template" field with a JSON skeleton suitable for the "request" argument of the invoke tool.
+ - "host": (Optional) Target address (host:port). Defaults to the ADDRESS environment variable.`),
 		WithStringArray("entities", mcp.Description("The services or messages type to describe (use dot notation)"), mcp.Required()),
+		mcp.WithBoolean("include_template", mcp.Description("Include a JSON request template for message and method types (default true)")),
+		mcp.WithString("host", mcp.Description("Target address; defaults to the ADDRESS environment variable")),
 	)
 	g.srv.AddTool(describeTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		// Create a gRPC client connection
-		network := "tcp"
-		target := g.host
-		dialTime := 10 * time.Second
-
-		dialOptions := []grpc.DialOption{
-			grpc.WithBlock(),
-			grpc.WithTimeout(dialTime),
-			grpc.WithInsecure(),
-		}
+		args := request.Params.Arguments
+		host, _ := args["host"].(string)
 
-		cc, err := grpcurl.BlockingDial(ctx, network, target, nil, dialOptions...)
+		// Reuse (or establish) a long-lived connection and descriptor source for this target.
+		conn, err := g.connFor(ctx, host)
 		if err != nil {
 			return toolError("Failed to create gRPC connection: " + err.Error()), nil
 		}
-		defer cc.Close()
-
-		// Create a reflection client and descriptor source
-		refClient := grpcreflect.NewClient(ctx, grpc_reflection_v1alpha.NewServerReflectionClient(cc))
-		defer refClient.Reset()
-		descSource := grpcurl.DescriptorSourceFromServer(ctx, refClient)
-
-		args := request.Params.Arguments
+		descSource := conn.descSource
 		entities, ok := args["entities"].(string)
 		var tmp []string
 		if ok {
@@ -334,7 +665,12 @@ Note: Slash notation (e.g., "mypackage.MyService/MyMethod") is used for invoking
 			return toolError("No entities provided"), nil
 		}
 
-		var results []string
+		includeTemplate := true
+		if v, ok := args["include_template"].(bool); ok {
+			includeTemplate = v
+		}
+
+		var results []describeResult
 
 		for _, entityStr := range tmp {
 			// Remove leading dot if present
@@ -350,11 +686,13 @@ Note: Slash notation (e.g., "mypackage.MyService/MyMethod") is used for invoking
 
 			fqn := dsc.GetFullyQualifiedName()
 			var elementType string
+			var templateSource *desc.MessageDescriptor
 
 			// Determine the type of the descriptor
 			switch d := dsc.(type) {
 			case *desc.MessageDescriptor:
 				elementType = "a message"
+				templateSource = d
 				if parent, ok := d.GetParent().(*desc.MessageDescriptor); ok {
 					if d.IsMapEntry() {
 						for _, f := range parent.GetFields() {
@@ -391,6 +729,7 @@ Note: Slash notation (e.g., "mypackage.MyService/MyMethod") is used for invoking
 				elementType = "a service"
 			case *desc.MethodDescriptor:
 				elementType = "a method"
+				templateSource = d.GetInputType()
 			default:
 				return toolError(fmt.Sprintf("descriptor has unrecognized type %T", dsc)), nil
 			}
@@ -403,25 +742,65 @@ Note: Slash notation (e.g., "mypackage.MyService/MyMethod") is used for invoking
 
 			description := fmt.Sprintf("%s is %s:\n%s", fqn, elementType, txt)
 
-			// // For message types, also show a JSON template
-			// if msgDesc, ok := dsc.(*desc.MessageDescriptor); ok {
-			// 	tmpl := grpcurl.MakeTemplate(msgDesc)
-			// 	options := grpcurl.FormatOptions{EmitJSONDefaultFields: true}
-			// 	_, formatter, err := grpcurl.RequestParserAndFormatter(grpcurl.FormatJSON, descSource, nil, options)
-			// 	if err != nil {
-			// 		return toolError(fmt.Sprintf("Failed to create formatter: %v", err)), nil
-			// 	}
-			// 	str, err := formatter(tmpl)
-			// 	if err != nil {
-			// 		return toolError(fmt.Sprintf("Failed to print template for message %s: %v", entityStr, err)), nil
-			// 	}
-			// 	description += "\nMessage template:\n" + str
-			// }
-
-			results = append(results, description)
-		}
-
-		return toolSuccess(strings.Join(results, "\n\n")), nil
+			// For message types (and, via its input type, method types) also
+			// emit a JSON template so LLM clients can immediately populate a
+			// valid "request" payload for the invoke tool.
+			var template string
+			if includeTemplate && templateSource != nil {
+				tmpl := grpcurl.MakeTemplate(templateSource)
+				_, tmplFormatter, ferr := grpcurl.RequestParserAndFormatter(grpcurl.FormatJSON, descSource, nil, grpcurl.FormatOptions{EmitJSONDefaultFields: true})
+				if ferr != nil {
+					return toolError(fmt.Sprintf("Failed to create template formatter: %v", ferr)), nil
+				}
+				str, ferr := tmplFormatter(tmpl)
+				if ferr != nil {
+					return toolError(fmt.Sprintf("Failed to render template for %q: %v", entityStr, ferr)), nil
+				}
+				template = str
+			}
+
+			results = append(results, describeResult{
+				Entity:      fqn,
+				Type:        elementType,
+				Description: description,
+				Template:    template,
+			})
+		}
+
+		jsonResponse, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			return toolError("Failed to marshal response: " + err.Error()), nil
+		}
+
+		return toolSuccess(string(jsonResponse)), nil
+	})
+
+	// Tool: refresh-schema
+	refreshSchemaTool := mcp.NewTool(
+		"refresh-schema",
+		mcp.WithDescription(`Forces the next invoke/list/describe call against a target to re-query its descriptor schema instead of using the cached one.
+Parameters:
+ - "host": (Optional) Target address whose cached schema should be invalidated. If omitted, all cached targets are refreshed.`),
+		mcp.WithString("host", mcp.Description("Target address; if omitted, all cached targets are refreshed")),
+	)
+	g.srv.AddTool(refreshSchemaTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		host, _ := request.Params.Arguments["host"].(string)
+		n := g.refreshSchema(host)
+		return toolSuccess(fmt.Sprintf("Invalidated cached schema for %d connection(s).", n)), nil
+	})
+
+	// Tool: disconnect
+	disconnectTool := mcp.NewTool(
+		"disconnect",
+		mcp.WithDescription(`Closes cached gRPC connections, forcing the next invoke/list/describe call to redial and re-discover the schema.
+Parameters:
+ - "host": (Optional) Target address to disconnect. If omitted, all cached connections are closed.`),
+		mcp.WithString("host", mcp.Description("Target address to disconnect; if omitted, all cached connections are closed")),
+	)
+	g.srv.AddTool(disconnectTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		host, _ := request.Params.Arguments["host"].(string)
+		n := g.disconnect(host)
+		return toolSuccess(fmt.Sprintf("Closed %d cached connection(s).", n)), nil
 	})
 
 	return
@@ -441,19 +820,270 @@ func metadataToMap(md metadata.MD) map[string]interface{} {
 	return result
 }
 
-// singleMessageSupplier implements grpcurl.RequestSupplier interface for a single message.
-type singleMessageSupplier struct {
-	data []byte
+// messageSupplier implements the grpcurl.RequestSupplier interface, walking an
+// ordered list of JSON-encoded request messages and returning io.EOF once the
+// last one has been supplied. A single-element list drives a unary or
+// server-streaming call; multiple elements drive client-streaming or
+// bidi-streaming calls.
+type messageSupplier struct {
+	messages [][]byte
+	idx      int
+}
+
+// Supply implements the grpcurl.RequestSupplier interface.
+func (s *messageSupplier) Supply(msg proto.Message) error {
+	if s.idx >= len(s.messages) {
+		return io.EOF
+	}
+	data := s.messages[s.idx]
+	s.idx++
+	return jsonpb.Unmarshal(bytes.NewReader(data), msg)
+}
+
+// findMethodDescriptor resolves the MethodDescriptor for a fully-qualified
+// method name in "package.Service/Method" form, so callers can inspect its
+// streaming kind before invoking it.
+func findMethodDescriptor(descSource grpcurl.DescriptorSource, method string) (*desc.MethodDescriptor, error) {
+	idx := strings.LastIndex(method, "/")
+	if idx < 0 {
+		return nil, fmt.Errorf("method name must be in the form package.Service/Method")
+	}
+	svcName, methName := method[:idx], method[idx+1:]
+
+	dsc, err := descSource.FindSymbol(svcName)
+	if err != nil {
+		return nil, err
+	}
+	svcDesc, ok := dsc.(*desc.ServiceDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a service", svcName)
+	}
+	methDesc := svcDesc.FindMethodByName(methName)
+	if methDesc == nil {
+		return nil, fmt.Errorf("service %s does not include a method named %s", svcName, methName)
+	}
+	return methDesc, nil
+}
+
+// parseRequestMessages splits a request payload into one or more JSON
+// messages. The payload may be a single JSON object, a JSON array of
+// objects, or a newline-delimited JSON stream (as accepted by grpcurl's
+// stdin mode) — the latter two forms supply the multiple request messages
+// that client-streaming and bidi-streaming RPCs require.
+func parseRequestMessages(payload string) ([][]byte, error) {
+	trimmed := strings.TrimSpace(payload)
+	if trimmed == "" {
+		return nil, nil
+	}
+
+	if trimmed[0] == '[' {
+		var raw []json.RawMessage
+		if err := json.Unmarshal([]byte(trimmed), &raw); err != nil {
+			return nil, fmt.Errorf("invalid JSON array: %w", err)
+		}
+		messages := make([][]byte, len(raw))
+		for i, m := range raw {
+			messages[i] = []byte(m)
+		}
+		return messages, nil
+	}
+
+	var messages [][]byte
+	dec := json.NewDecoder(strings.NewReader(trimmed))
+	for dec.More() {
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return nil, fmt.Errorf("invalid JSON: %w", err)
+		}
+		messages = append(messages, []byte(raw))
+	}
+	return messages, nil
+}
+
+// dynamicMessageSupplier implements grpcurl.RequestSupplier, copying a single
+// already-built dynamic.Message into the message grpcurl.InvokeRPC supplies.
+// Used by invoke-http, which builds its request by binding path/query/body
+// values into a dynamic.Message rather than unmarshaling it from JSON as-is.
+type dynamicMessageSupplier struct {
+	msg  *dynamic.Message
 	used bool
 }
 
 // Supply implements the grpcurl.RequestSupplier interface.
-func (s *singleMessageSupplier) Supply(msg proto.Message) error {
+func (s *dynamicMessageSupplier) Supply(target proto.Message) error {
 	if s.used {
 		return io.EOF
 	}
 	s.used = true
-	return jsonpb.Unmarshal(bytes.NewReader(s.data), msg)
+	return s.msg.MergeInto(target)
+}
+
+// httpRuleForMethod reads a method's google.api.http option and returns the
+// primary binding's HTTP verb, URL template, and body selector. Only the
+// first pattern is used; additional_bindings are not considered.
+func httpRuleForMethod(methodDesc *desc.MethodDescriptor) (httpMethod, pathTemplate, bodySelector string, err error) {
+	opts := methodDesc.GetMethodOptions()
+	if opts == nil {
+		return "", "", "", fmt.Errorf("method %s has no google.api.http annotation", methodDesc.GetFullyQualifiedName())
+	}
+	ext, err := proto.GetExtension(opts, annotations.E_Http)
+	if err != nil {
+		return "", "", "", fmt.Errorf("method %s has no google.api.http annotation: %w", methodDesc.GetFullyQualifiedName(), err)
+	}
+	rule, ok := ext.(*annotations.HttpRule)
+	if !ok || rule == nil {
+		return "", "", "", fmt.Errorf("method %s has no google.api.http annotation", methodDesc.GetFullyQualifiedName())
+	}
+
+	switch pattern := rule.GetPattern().(type) {
+	case *annotations.HttpRule_Get:
+		return http.MethodGet, pattern.Get, rule.GetBody(), nil
+	case *annotations.HttpRule_Put:
+		return http.MethodPut, pattern.Put, rule.GetBody(), nil
+	case *annotations.HttpRule_Post:
+		return http.MethodPost, pattern.Post, rule.GetBody(), nil
+	case *annotations.HttpRule_Delete:
+		return http.MethodDelete, pattern.Delete, rule.GetBody(), nil
+	case *annotations.HttpRule_Patch:
+		return http.MethodPatch, pattern.Patch, rule.GetBody(), nil
+	case *annotations.HttpRule_Custom:
+		return pattern.Custom.GetKind(), pattern.Custom.GetPath(), rule.GetBody(), nil
+	default:
+		return "", "", "", fmt.Errorf("method %s has an empty google.api.http pattern", methodDesc.GetFullyQualifiedName())
+	}
+}
+
+// parseHTTPPathVars walks a grpc-gateway URL template such as
+// "/v1/{parent}/books/{book.id=*}" and returns the field path named by each
+// "{var}" or "{var=pattern}" segment, in order of appearance.
+func parseHTTPPathVars(template string) []string {
+	var vars []string
+	for i := 0; i < len(template); {
+		start := strings.IndexByte(template[i:], '{')
+		if start < 0 {
+			break
+		}
+		start += i
+		end := strings.IndexByte(template[start:], '}')
+		if end < 0 {
+			break
+		}
+		end += start
+
+		name := template[start+1 : end]
+		if eq := strings.IndexByte(name, '='); eq >= 0 {
+			name = name[:eq]
+		}
+		vars = append(vars, name)
+		i = end + 1
+	}
+	return vars
+}
+
+// setFieldByPath sets the field at the given dot-separated path (e.g.
+// "parent.name") on msg to value, creating intermediate nested messages as
+// needed and converting value to the leaf field's scalar type.
+func setFieldByPath(msg *dynamic.Message, path string, value interface{}) error {
+	cur := msg
+	parts := strings.Split(path, ".")
+	for i, part := range parts {
+		fd := cur.GetMessageDescriptor().FindFieldByName(part)
+		if fd == nil {
+			return fmt.Errorf("no field named %q on %s", part, cur.GetMessageDescriptor().GetFullyQualifiedName())
+		}
+
+		if i == len(parts)-1 {
+			converted, err := convertScalarForField(fd, value)
+			if err != nil {
+				return err
+			}
+			return cur.TrySetField(fd, converted)
+		}
+
+		next, err := cur.TryGetField(fd)
+		if err != nil {
+			return err
+		}
+		nextMsg, ok := next.(*dynamic.Message)
+		if !ok {
+			nextMsg = dynamic.NewMessage(fd.GetMessageType())
+			if err := cur.TrySetField(fd, nextMsg); err != nil {
+				return err
+			}
+		}
+		cur = nextMsg
+	}
+	return nil
+}
+
+// convertScalarForField converts a generic JSON value (as decoded from the
+// "params" argument) to the Go type dynamic.Message expects for
+// the given field, following grpc-gateway's string-to-scalar conversions.
+func convertScalarForField(fd *desc.FieldDescriptor, value interface{}) (interface{}, error) {
+	str := fmt.Sprintf("%v", value)
+	switch fd.GetType() {
+	case descriptorpb.FieldDescriptorProto_TYPE_STRING:
+		return str, nil
+	case descriptorpb.FieldDescriptorProto_TYPE_BOOL:
+		return strconv.ParseBool(str)
+	case descriptorpb.FieldDescriptorProto_TYPE_INT32, descriptorpb.FieldDescriptorProto_TYPE_SINT32, descriptorpb.FieldDescriptorProto_TYPE_SFIXED32:
+		v, err := strconv.ParseInt(str, 10, 32)
+		return int32(v), err
+	case descriptorpb.FieldDescriptorProto_TYPE_INT64, descriptorpb.FieldDescriptorProto_TYPE_SINT64, descriptorpb.FieldDescriptorProto_TYPE_SFIXED64:
+		return strconv.ParseInt(str, 10, 64)
+	case descriptorpb.FieldDescriptorProto_TYPE_UINT32, descriptorpb.FieldDescriptorProto_TYPE_FIXED32:
+		v, err := strconv.ParseUint(str, 10, 32)
+		return uint32(v), err
+	case descriptorpb.FieldDescriptorProto_TYPE_UINT64, descriptorpb.FieldDescriptorProto_TYPE_FIXED64:
+		return strconv.ParseUint(str, 10, 64)
+	case descriptorpb.FieldDescriptorProto_TYPE_FLOAT:
+		v, err := strconv.ParseFloat(str, 32)
+		return float32(v), err
+	case descriptorpb.FieldDescriptorProto_TYPE_DOUBLE:
+		return strconv.ParseFloat(str, 64)
+	case descriptorpb.FieldDescriptorProto_TYPE_ENUM:
+		enumVal := fd.GetEnumType().FindValueByName(str)
+		if enumVal == nil {
+			return nil, fmt.Errorf("%q is not a valid value of enum %s", str, fd.GetEnumType().GetFullyQualifiedName())
+		}
+		return enumVal.GetNumber(), nil
+	default:
+		return nil, fmt.Errorf("field %s has a type that cannot be bound from a path or query parameter", fd.GetFullyQualifiedName())
+	}
+}
+
+// httpStatusForCode maps a gRPC status code to its grpc-gateway-equivalent
+// HTTP status, per google.golang.org/genproto/googleapis/rpc/code's mapping
+// of canonical error codes to HTTP.
+func httpStatusForCode(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.Canceled:
+		return 499 // Client Closed Request (no standard http constant)
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	case codes.Unknown, codes.Internal, codes.DataLoss:
+		return http.StatusInternalServerError
+	default:
+		return http.StatusInternalServerError
+	}
 }
 
 func main() {
@@ -519,6 +1149,385 @@ func toolError(message string) *mcp.CallToolResult {
 // GrpcReflectionServer wraps grpcurl functionalities into an MCP server.
 type GrpcReflectionServer struct {
 	srv     *server.MCPServer
-	host    string
+	host    string            // Default target, used when a tool call omits "host"
 	headers map[string]string // Global headers to be used with all requests
+	connCfg connectionConfig  // Dialing options applied to all future connections
+
+	protoSource     grpcurl.DescriptorSource // Descriptor source loaded via load-protos, if any
+	composeProtos   bool                     // If true, merge protoSource with server reflection instead of replacing it
+	protoGeneration uint64                   // Bumped every time load-protos/clear changes protoSource or composeProtos
+
+	schemaTTL time.Duration // How long a cached connection's schema is trusted before re-querying
+
+	connMu    sync.Mutex
+	connCache map[string]*cachedConn // Keyed by target + credentials fingerprint + proto generation
+}
+
+// bumpProtoGeneration invalidates every cached connection's descriptor
+// source by changing the generation component of the cache key, so the
+// next connFor call for a target rebuilds its descSource from the current
+// protoSource/composeProtos instead of silently reusing one built before
+// load-protos was called. The superseded entries are closed lazily, by
+// connFor, the next time that target is dialed.
+func (g *GrpcReflectionServer) bumpProtoGeneration() {
+	g.connMu.Lock()
+	defer g.connMu.Unlock()
+	g.protoGeneration++
+}
+
+// cachedConn is a long-lived connection to one target, reused across tool
+// calls instead of being dialed and torn down every time.
+type cachedConn struct {
+	cc         *grpc.ClientConn
+	refClient  *grpcreflect.Client
+	descSource *memoizingDescriptorSource
+}
+
+// connFor returns the long-lived connection and descriptor source for
+// target (or g.host, if target is empty), dialing and running reflection
+// discovery only the first time a given (target, credentials, proto
+// generation) triple is seen. Reconfiguring credentials via
+// configure-connection or loading/clearing descriptors via load-protos
+// changes that triple, so the next call here dials (or rebuilds the
+// descriptor source) fresh and closes out the entry it superseded.
+// Use refreshSchema/disconnect to invalidate or tear down a cached entry
+// without otherwise changing connCfg/protoSource.
+func (g *GrpcReflectionServer) connFor(ctx context.Context, target string) (*cachedConn, error) {
+	if target == "" {
+		target = g.host
+	}
+	if target == "" {
+		return nil, fmt.Errorf("no target host: pass \"host\" or set the ADDRESS environment variable")
+	}
+
+	key := fmt.Sprintf("%s|%s|%d", target, g.connCfg.fingerprint(), g.protoGeneration)
+
+	g.connMu.Lock()
+	defer g.connMu.Unlock()
+
+	if entry, ok := g.connCache[key]; ok {
+		return entry, nil
+	}
+
+	// Dialing options or loaded descriptors changed since the last entry was
+	// cached for this target: that entry is superseded and would otherwise
+	// leak its connection for the life of the process, so close it now.
+	prefix := target + "|"
+	for k, entry := range g.connCache {
+		if strings.HasPrefix(k, prefix) {
+			entry.refClient.Reset()
+			entry.cc.Close()
+			delete(g.connCache, k)
+		}
+	}
+
+	cc, err := g.dialTarget(ctx, target)
+	if err != nil {
+		return nil, err
+	}
+
+	refClient := grpcreflect.NewClient(ctx, grpc_reflection_v1alpha.NewServerReflectionClient(cc))
+	reflectionSource := grpcurl.DescriptorSourceFromServer(ctx, refClient)
+
+	var base grpcurl.DescriptorSource = reflectionSource
+	if g.protoSource != nil {
+		if g.composeProtos {
+			base = compositeDescriptorSource{primary: g.protoSource, fallback: reflectionSource}
+		} else {
+			base = g.protoSource
+		}
+	}
+
+	entry := &cachedConn{
+		cc:         cc,
+		refClient:  refClient,
+		descSource: newMemoizingDescriptorSource(base, &g.schemaTTL),
+	}
+	g.connCache[key] = entry
+	return entry, nil
+}
+
+// refreshSchema drops the memoized ListServices/FindSymbol results for a
+// cached connection (or all of them, if target is empty), so the next tool
+// call re-queries the server or local proto source.
+func (g *GrpcReflectionServer) refreshSchema(target string) int {
+	g.connMu.Lock()
+	defer g.connMu.Unlock()
+
+	n := 0
+	for key, entry := range g.connCache {
+		if target != "" && !strings.HasPrefix(key, target+"|") {
+			continue
+		}
+		entry.descSource.invalidate()
+		n++
+	}
+	return n
+}
+
+// disconnect closes and forgets cached connections for target (or all of
+// them, if target is empty).
+func (g *GrpcReflectionServer) disconnect(target string) int {
+	g.connMu.Lock()
+	defer g.connMu.Unlock()
+
+	n := 0
+	for key, entry := range g.connCache {
+		if target != "" && !strings.HasPrefix(key, target+"|") {
+			continue
+		}
+		entry.refClient.Reset()
+		entry.cc.Close()
+		delete(g.connCache, key)
+		n++
+	}
+	return n
+}
+
+// memoizingDescriptorSource wraps a DescriptorSource and caches its
+// ListServices/FindSymbol results for ttl (a pointer so it tracks live
+// updates to GrpcReflectionServer.schemaTTL), so repeated tool calls
+// against the same connection don't re-run discovery on every call.
+// AllExtensionsForType is passed through to the underlying source uncached.
+type memoizingDescriptorSource struct {
+	underlying grpcurl.DescriptorSource
+	ttl        *time.Duration
+
+	mu         sync.Mutex
+	services   []string
+	servicesAt time.Time
+	symbols    map[string]desc.Descriptor
+	symbolsAt  map[string]time.Time
+}
+
+// newMemoizingDescriptorSource wraps underlying with a cache whose entries
+// are trusted for *ttl before being re-queried.
+func newMemoizingDescriptorSource(underlying grpcurl.DescriptorSource, ttl *time.Duration) *memoizingDescriptorSource {
+	return &memoizingDescriptorSource{
+		underlying: underlying,
+		ttl:        ttl,
+		symbols:    make(map[string]desc.Descriptor),
+		symbolsAt:  make(map[string]time.Time),
+	}
+}
+
+func (m *memoizingDescriptorSource) ListServices() ([]string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.services != nil && time.Since(m.servicesAt) < *m.ttl {
+		return m.services, nil
+	}
+
+	services, err := m.underlying.ListServices()
+	if err != nil {
+		return nil, err
+	}
+	m.services = services
+	m.servicesAt = time.Now()
+	return services, nil
+}
+
+func (m *memoizingDescriptorSource) FindSymbol(fullyQualifiedName string) (desc.Descriptor, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if dsc, ok := m.symbols[fullyQualifiedName]; ok && time.Since(m.symbolsAt[fullyQualifiedName]) < *m.ttl {
+		return dsc, nil
+	}
+
+	dsc, err := m.underlying.FindSymbol(fullyQualifiedName)
+	if err != nil {
+		return nil, err
+	}
+	m.symbols[fullyQualifiedName] = dsc
+	m.symbolsAt[fullyQualifiedName] = time.Now()
+	return dsc, nil
+}
+
+func (m *memoizingDescriptorSource) AllExtensionsForType(typeName string) ([]*desc.FieldDescriptor, error) {
+	return m.underlying.AllExtensionsForType(typeName)
+}
+
+// invalidate drops all cached results, forcing the next call to re-query
+// the underlying descriptor source.
+func (m *memoizingDescriptorSource) invalidate() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.services = nil
+	m.servicesAt = time.Time{}
+	m.symbols = make(map[string]desc.Descriptor)
+	m.symbolsAt = make(map[string]time.Time)
+}
+
+// compositeDescriptorSource merges a locally-loaded descriptor source with
+// server reflection, so a partially-reflective server's symbols can be
+// supplemented with locally-known ones. The local source is consulted first.
+type compositeDescriptorSource struct {
+	primary  grpcurl.DescriptorSource
+	fallback grpcurl.DescriptorSource
+}
+
+func (c compositeDescriptorSource) ListServices() ([]string, error) {
+	primarySvcs, err := c.primary.ListServices()
+	if err != nil {
+		return nil, err
+	}
+	fallbackSvcs, err := c.fallback.ListServices()
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool, len(primarySvcs)+len(fallbackSvcs))
+	merged := make([]string, 0, len(primarySvcs)+len(fallbackSvcs))
+	for _, lists := range [][]string{primarySvcs, fallbackSvcs} {
+		for _, svc := range lists {
+			if !seen[svc] {
+				seen[svc] = true
+				merged = append(merged, svc)
+			}
+		}
+	}
+	return merged, nil
+}
+
+func (c compositeDescriptorSource) FindSymbol(fullyQualifiedName string) (desc.Descriptor, error) {
+	if dsc, err := c.primary.FindSymbol(fullyQualifiedName); err == nil {
+		return dsc, nil
+	}
+	return c.fallback.FindSymbol(fullyQualifiedName)
+}
+
+func (c compositeDescriptorSource) AllExtensionsForType(typeName string) ([]*desc.FieldDescriptor, error) {
+	primaryExts, _ := c.primary.AllExtensionsForType(typeName)
+	fallbackExts, err := c.fallback.AllExtensionsForType(typeName)
+	if err != nil {
+		return primaryExts, nil
+	}
+	return append(primaryExts, fallbackExts...), nil
+}
+
+// descriptorSourceFromProtosetBytes builds a DescriptorSource from a
+// serialized FileDescriptorSet, the format produced by
+// `protoc --descriptor_set_out`.
+func descriptorSourceFromProtosetBytes(data []byte) (grpcurl.DescriptorSource, error) {
+	var fdSet descriptorpb.FileDescriptorSet
+	if err := proto.Unmarshal(data, &fdSet); err != nil {
+		return nil, fmt.Errorf("invalid protoset: %w", err)
+	}
+	fdMap, err := desc.CreateFileDescriptorsFromSet(&fdSet)
+	if err != nil {
+		return nil, err
+	}
+	fds := make([]*desc.FileDescriptor, 0, len(fdMap))
+	for _, fd := range fdMap {
+		fds = append(fds, fd)
+	}
+	return grpcurl.DescriptorSourceFromFileDescriptors(fds...)
+}
+
+// stringArrayArg reads an MCP argument that may be provided as a JSON array
+// of strings or, for convenience, a single comma-separated string.
+func stringArrayArg(args map[string]interface{}, name string) []string {
+	switch v := args[name].(type) {
+	case []interface{}:
+		out := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	case string:
+		if v == "" {
+			return nil
+		}
+		return strings.Split(v, ",")
+	default:
+		return nil
+	}
+}
+
+// connectionConfig holds the dialing options set via the configure-connection
+// tool and shared by the invoke, list, and describe tools.
+type connectionConfig struct {
+	Plaintext          bool   // Dial without TLS (the default).
+	InsecureSkipVerify bool   // Skip server certificate verification when using TLS.
+	CACert             string // PEM-encoded CA certificate used to verify the server.
+	ClientCert         string // PEM-encoded client certificate, for mutual TLS.
+	ClientKey          string // PEM-encoded client key, for mutual TLS.
+	ServerName         string // Overrides the server name used for SNI and certificate verification.
+	Unix               bool   // Dial the target as a Unix domain socket instead of TCP.
+}
+
+// transportCredentials builds gRPC transport credentials from the configured
+// options, returning nil when c.Plaintext is set so callers fall back to an
+// insecure connection.
+func (c connectionConfig) transportCredentials() (credentials.TransportCredentials, error) {
+	if c.Plaintext {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: c.InsecureSkipVerify,
+		ServerName:         c.ServerName,
+	}
+
+	if c.CACert != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(c.CACert)) {
+			return nil, fmt.Errorf("failed to parse ca_cert PEM")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if c.ClientCert != "" || c.ClientKey != "" {
+		cert, err := tls.X509KeyPair([]byte(c.ClientCert), []byte(c.ClientKey))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse client_cert/client_key: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return credentials.NewTLS(tlsConfig), nil
+}
+
+// fingerprint identifies a connectionConfig for cache-keying purposes: two
+// configs with the same fingerprint dial identically.
+func (c connectionConfig) fingerprint() string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%t|%t|%t|%s|%s|%s|%s", c.Plaintext, c.InsecureSkipVerify, c.Unix, c.CACert, c.ClientCert, c.ClientKey, c.ServerName)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// dialTarget opens a gRPC client connection to target, applying whatever
+// TLS/mTLS settings were set via the configure-connection tool. Call
+// connFor instead of this directly — it dials (via dialTarget) only when no
+// cached connection matches the target and current settings, and reuses the
+// connection afterwards.
+func (g *GrpcReflectionServer) dialTarget(ctx context.Context, target string) (*grpc.ClientConn, error) {
+	// grpcurl.BlockingDial's "network" parameter is unused by the underlying
+	// dialer (it always dials via grpc.DialContext, which resolves the
+	// target by scheme); a unix socket has to be requested through the
+	// address itself, using the "unix:" scheme grpc-go's default resolver
+	// understands.
+	if g.connCfg.Unix && !strings.HasPrefix(target, "unix:") {
+		target = "unix:" + target
+	}
+
+	dialOptions := []grpc.DialOption{
+		grpc.WithBlock(),
+		grpc.WithTimeout(10 * time.Second),
+	}
+
+	creds, err := g.connCfg.transportCredentials()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build TLS credentials: %w", err)
+	}
+	if creds == nil {
+		dialOptions = append(dialOptions, grpc.WithInsecure())
+	}
+
+	return grpcurl.BlockingDial(ctx, "tcp", target, creds, dialOptions...)
 }